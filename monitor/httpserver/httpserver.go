@@ -0,0 +1,193 @@
+// monitor/httpserver/httpserver.go
+// Package httpserver exposes the GitHub Actions monitor's live state over
+// HTTP: a JSON status snapshot, a log tail (with optional SSE follow), and
+// Prometheus metrics, so operators can watch a deployment from a browser
+// instead of the GitLab CI console.
+package httpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds the Prometheus collectors updated by the monitor's GitHub
+// and GitLab API calls.
+type Metrics struct {
+	GitHubRequestsTotal      *prometheus.CounterVec
+	GitHubRequestDuration    *prometheus.HistogramVec
+	GitLabStatusUpdatesTotal *prometheus.CounterVec
+	WorkflowRunDuration      *prometheus.HistogramVec
+}
+
+// NewMetrics creates and registers the monitor's Prometheus collectors.
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		GitHubRequestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "github_api_requests_total",
+			Help: "Total GitHub API requests made by the monitor, by response code.",
+		}, []string{"code"}),
+		GitHubRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "github_api_request_duration_seconds",
+			Help: "GitHub API request latency in seconds, by endpoint.",
+		}, []string{"endpoint"}),
+		GitLabStatusUpdatesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gitlab_status_updates_total",
+			Help: "Total GitLab external status updates posted, by state.",
+		}, []string{"state"}),
+		WorkflowRunDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "workflow_run_duration_seconds",
+			Help: "Observed GitHub Actions workflow run duration in seconds, by conclusion.",
+		}, []string{"conclusion"}),
+	}
+
+	prometheus.MustRegister(
+		m.GitHubRequestsTotal,
+		m.GitHubRequestDuration,
+		m.GitLabStatusUpdatesTotal,
+		m.WorkflowRunDuration,
+	)
+
+	return m
+}
+
+// LogResolver maps an environment name to the log file the monitor is
+// writing for it, and whether that environment is known.
+type LogResolver func(environment string) (path string, ok bool)
+
+// Server serves /status, /logs/{env}, and /metrics for a running monitor.
+type Server struct {
+	statusFunc func() interface{}
+	resolveLog LogResolver
+}
+
+// NewServer builds a Server. statusFunc must return a JSON-marshalable
+// snapshot of every tracked target's current state; resolveLog maps an
+// environment name to its log file.
+func NewServer(statusFunc func() interface{}, resolveLog LogResolver) *Server {
+	return &Server{statusFunc: statusFunc, resolveLog: resolveLog}
+}
+
+// ListenAndServe starts the status HTTP server on addr. It's meant to run
+// in its own goroutine alongside the monitor's poll loop.
+func (s *Server) ListenAndServe(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", s.handleStatus)
+	mux.HandleFunc("/logs/", s.handleLogs)
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return http.ListenAndServe(addr, mux)
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.statusFunc()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
+	environment := strings.TrimPrefix(r.URL.Path, "/logs/")
+	if environment == "" {
+		http.Error(w, "environment is required", http.StatusBadRequest)
+		return
+	}
+
+	path, ok := s.resolveLog(environment)
+	if !ok {
+		http.Error(w, fmt.Sprintf("no log file tracked for environment %q", environment), http.StatusNotFound)
+		return
+	}
+
+	if r.URL.Query().Get("follow") == "1" {
+		s.streamLog(w, r, path)
+		return
+	}
+
+	http.ServeFile(w, r, path)
+}
+
+// streamLog tails path over Server-Sent Events, using fsnotify to wake up
+// on writes instead of polling the file.
+func (s *Server) streamLog(w http.ResponseWriter, r *http.Request, path string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	file, err := os.Open(path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	// Start from the end of the file; GET /logs/{env} without ?follow is
+	// the way to fetch history instead.
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(path); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	buf := make([]byte, 4096)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+
+			for {
+				n, readErr := file.Read(buf)
+				if n > 0 {
+					chunk := strings.ReplaceAll(string(buf[:n]), "\n", "\ndata: ")
+					fmt.Fprintf(w, "data: %s\n\n", chunk)
+					flusher.Flush()
+				}
+				if readErr == io.EOF {
+					break
+				}
+				if readErr != nil {
+					return
+				}
+			}
+
+		case watchErr, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			fmt.Fprintf(w, "event: error\ndata: %s\n\n", watchErr)
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// monitor/httpserver/httpserver_test.go
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestHandleStatusEncodesStatusFunc checks that GET /status returns
+// statusFunc's value JSON-encoded.
+func TestHandleStatusEncodesStatusFunc(t *testing.T) {
+	s := NewServer(func() interface{} {
+		return map[string]string{"main": "success"}
+	}, func(string) (string, bool) { return "", false })
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/status", nil)
+	s.handleStatus(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d; want %d", rec.Code, http.StatusOK)
+	}
+
+	var got map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	if got["main"] != "success" {
+		t.Errorf("body[\"main\"] = %q; want %q", got["main"], "success")
+	}
+}
+
+// TestHandleLogsMissingEnvironment checks that a bare /logs/ request is
+// rejected before resolveLog is even consulted.
+func TestHandleLogsMissingEnvironment(t *testing.T) {
+	s := NewServer(func() interface{} { return nil }, func(string) (string, bool) {
+		t.Fatal("resolveLog should not be called for an empty environment")
+		return "", false
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/logs/", nil)
+	s.handleLogs(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+// TestHandleLogsUnknownEnvironment checks that an environment resolveLog
+// doesn't recognize returns 404.
+func TestHandleLogsUnknownEnvironment(t *testing.T) {
+	s := NewServer(func() interface{} { return nil }, func(string) (string, bool) { return "", false })
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/logs/staging", nil)
+	s.handleLogs(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+// TestHandleLogsServesFile checks that a known environment's log file is
+// served back verbatim.
+func TestHandleLogsServesFile(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "qa.log")
+	want := "[2026-07-26 00:00:00] hello\n"
+	if err := os.WriteFile(logPath, []byte(want), 0644); err != nil {
+		t.Fatalf("WriteFile() error: %v", err)
+	}
+
+	s := NewServer(func() interface{} { return nil }, func(environment string) (string, bool) {
+		if environment != "qa" {
+			return "", false
+		}
+		return logPath, true
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/logs/qa", nil)
+	s.handleLogs(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d; want %d", rec.Code, http.StatusOK)
+	}
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body = %q; want %q", got, want)
+	}
+}
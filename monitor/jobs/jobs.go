@@ -0,0 +1,21 @@
+// monitor/jobs/jobs.go
+// Package jobs identifies the (branch, workflow) targets a Supervisor
+// monitors concurrently.
+package jobs
+
+// Target identifies a single (branch, workflow) pair being monitored. An
+// empty Workflow means "match any workflow on this branch", preserving the
+// single-branch behavior of the original monitor.
+type Target struct {
+	Branch   string
+	Workflow string
+}
+
+// String returns a human-readable label, used in log lines and as a map
+// key for per-target state.
+func (t Target) String() string {
+	if t.Workflow == "" {
+		return t.Branch
+	}
+	return t.Branch + "/" + t.Workflow
+}
@@ -0,0 +1,23 @@
+// monitor/jobs/jobs_test.go
+package jobs
+
+import (
+	"testing"
+)
+
+// TestTargetString covers the single-workflow-vs-any-workflow label format.
+func TestTargetString(t *testing.T) {
+	tests := []struct {
+		target Target
+		want   string
+	}{
+		{Target{Branch: "main"}, "main"},
+		{Target{Branch: "main", Workflow: "ci"}, "main/ci"},
+	}
+
+	for _, test := range tests {
+		if got := test.target.String(); got != test.want {
+			t.Errorf("Target%+v.String() = %q; want %q", test.target, got, test.want)
+		}
+	}
+}
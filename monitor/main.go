@@ -5,14 +5,22 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/vishalkumar1007/Go-Server/monitor/httpserver"
+	"github.com/vishalkumar1007/Go-Server/monitor/jobs"
+	"github.com/vishalkumar1007/Go-Server/monitor/notify"
+	statestore "github.com/vishalkumar1007/Go-Server/monitor/state"
 )
 
 // GitHub API Response Structures
@@ -71,12 +79,76 @@ type GitHubActionsMonitor struct {
 	GitLabToken     string
 	GitLabProjectID string
 	BranchName      string // Changed from CommitSHA to BranchName
+	WorkflowName    string // Optional: restrict matching to a single workflow name
 	Environment     string // New: environment name (dev, qa, prod, next)
 	LogFile         string
 	APILogFile      string
 	PollInterval    time.Duration
 	HTTPClient      *http.Client
 	LogsDir         string
+	MaxLogArchiveMB int64
+	StateDir        string
+
+	// RateLimitThreshold is the X-RateLimit-Remaining floor below which
+	// makeGitHubRequest starts backing off PollInterval.
+	RateLimitThreshold int
+	// MaxPollInterval caps how far backoff can stretch PollInterval.
+	MaxPollInterval time.Duration
+
+	// cacheMu guards cache, an in-memory ETag/Last-Modified cache keyed by
+	// request URL so unchanged GitHub responses cost a 304 instead of a
+	// full re-fetch.
+	cacheMu sync.Mutex
+	cache   map[string]*cachedGitHubResponse
+
+	// rateLimitMu guards rateLimit, the last GitHub rate limit snapshot.
+	rateLimitMu sync.Mutex
+	rateLimit   RateLimitStats
+
+	// requestSem gates concurrent GitHub API calls across every monitor
+	// sharing this semaphore, so a Supervisor running several targets stays
+	// under GitHub's 5000 req/hr rate limit. nil means "unbounded", which
+	// preserves the behavior of a lone, directly-constructed monitor.
+	requestSem chan struct{}
+
+	// stateStore records per-(branch, workflow) progress so a restart or
+	// CI retry doesn't re-report transitions GitLab has already seen. nil
+	// disables persistence (e.g. when the state store failed to open).
+	stateStore *statestore.Store
+
+	// metrics, when non-nil, receives Prometheus observations from
+	// makeGitHubRequest, updateGitLabStatus, and workflow completions, for
+	// the optional HTTP status server to expose via /metrics.
+	metrics *httpserver.Metrics
+
+	// onSnapshot, when non-nil, is called with every successfully fetched
+	// workflow run for this target, letting a Supervisor keep its /status
+	// snapshot current without gm needing to know about Supervisor.
+	onSnapshot func(run *GitHubWorkflowRun)
+
+	// onStatusChanged, when non-nil, is called with a notify.StatusEvent
+	// every time this target's status or conclusion changes, letting a
+	// Supervisor fan it out to the configured notification sinks.
+	onStatusChanged func(event notify.StatusEvent)
+}
+
+// cachedGitHubResponse is the last successful response makeGitHubRequest
+// saw for a given URL, kept so a future 304 Not Modified can be served
+// from memory instead of re-downloading the full GitHub response. Only the
+// raw body is cached: callers decode it again on every request.
+type cachedGitHubResponse struct {
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+// RateLimitStats is a snapshot of the last observed GitHub rate limit
+// headers, exposed so the HTTP status endpoint (and logs) can show
+// remaining budget.
+type RateLimitStats struct {
+	Remaining int
+	Limit     int
+	ResetAt   time.Time
 }
 
 // NewGitHubActionsMonitor creates a new monitor instance
@@ -93,19 +165,355 @@ func NewGitHubActionsMonitor() *GitHubActionsMonitor {
 		environment = "unknown"
 	}
 
+	maxLogArchiveMB := int64(200)
+	if maxMB := os.Getenv("MAX_LOG_ARCHIVE_MB"); maxMB != "" {
+		if parsed, err := strconv.ParseInt(maxMB, 10, 64); err == nil && parsed > 0 {
+			maxLogArchiveMB = parsed
+		}
+	}
+
+	stateDir := os.Getenv("STATE_DIR")
+	if stateDir == "" {
+		stateDir = "gitlab-logs/state"
+	}
+
+	rateLimitThreshold := 100
+	if raw := os.Getenv("RATE_LIMIT_THRESHOLD"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			rateLimitThreshold = parsed
+		}
+	}
+
+	maxPollInterval := 5 * time.Minute
+	if raw := os.Getenv("MAX_POLL_INTERVAL"); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil {
+			maxPollInterval = parsed
+		}
+	}
+
 	logsDir := "gitlab-logs"
-	return &GitHubActionsMonitor{
-		GitHubToken:     os.Getenv("GITHUB_TOKEN"),
-		GitHubRepo:      os.Getenv("GITHUB_REPO"),
-		GitLabToken:     os.Getenv("GITLAB_TOKEN"),
-		GitLabProjectID: os.Getenv("GITLAB_PROJECT_ID"),
-		BranchName:      os.Getenv("BRANCH_NAME"), // Read branch name from env
-		Environment:     environment,
-		LogsDir:         logsDir,
-		LogFile:         filepath.Join(logsDir, fmt.Sprintf("github-deployment-%s.log", environment)),
-		APILogFile:      filepath.Join(logsDir, fmt.Sprintf("github-api-responses-%s.log", environment)),
-		PollInterval:    pollInterval,
-		HTTPClient:      &http.Client{Timeout: 30 * time.Second},
+	gm := &GitHubActionsMonitor{
+		GitHubToken:        os.Getenv("GITHUB_TOKEN"),
+		GitHubRepo:         os.Getenv("GITHUB_REPO"),
+		GitLabToken:        os.Getenv("GITLAB_TOKEN"),
+		GitLabProjectID:    os.Getenv("GITLAB_PROJECT_ID"),
+		BranchName:         os.Getenv("BRANCH_NAME"), // Read branch name from env
+		Environment:        environment,
+		LogsDir:            logsDir,
+		LogFile:            filepath.Join(logsDir, fmt.Sprintf("github-deployment-%s.log", environment)),
+		APILogFile:         filepath.Join(logsDir, fmt.Sprintf("github-api-responses-%s.log", environment)),
+		PollInterval:       pollInterval,
+		HTTPClient:         &http.Client{Timeout: 30 * time.Second},
+		MaxLogArchiveMB:    maxLogArchiveMB,
+		StateDir:           stateDir,
+		RateLimitThreshold: rateLimitThreshold,
+		MaxPollInterval:    maxPollInterval,
+		cache:              make(map[string]*cachedGitHubResponse),
+	}
+
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		fmt.Printf("WARNING: Cannot create state directory %s: %v\n", stateDir, err)
+		return gm
+	}
+
+	store, err := statestore.Open(filepath.Join(stateDir, "monitor.db"))
+	if err != nil {
+		fmt.Printf("WARNING: Cannot open state store, transitions will not be deduplicated across restarts: %v\n", err)
+		return gm
+	}
+	gm.stateStore = store
+
+	return gm
+}
+
+// Supervisor runs one GitHubActionsMonitor per (branch, workflow) target
+// concurrently, sharing a single rate-limited GitHub client across all of
+// them. A global semaphore (requestSem) limits concurrent GitHub calls
+// across every target to respect the 5000 req/hr limit.
+type Supervisor struct {
+	Targets       []jobs.Target
+	GlobalTimeout time.Duration
+
+	base       *GitHubActionsMonitor // template holding shared config (tokens, repo, environment, poll interval...)
+	requestSem chan struct{}
+
+	httpAddr   string
+	metrics    *httpserver.Metrics
+	dispatcher *notify.Dispatcher
+
+	mu       sync.Mutex
+	snapshot map[string]*GitHubWorkflowRun     // keyed by jobs.Target.String()
+	monitors map[string]*GitHubActionsMonitor // keyed by jobs.Target.String(), for rate limit stats
+	logFiles map[string]string                // environment -> log file path
+
+	done chan struct{}
+}
+
+// maxConcurrentGitHubRequests caps in-flight GitHub API calls across every
+// target a Supervisor manages, keeping well under the 5000 req/hr quota
+// even when many branches/workflows are monitored at once.
+const maxConcurrentGitHubRequests = 4
+
+// maxConcurrentNotifications caps how many notification sink deliveries
+// run at once across targets. Dispatch still starts one goroutine per sink
+// per event; deliveries beyond the cap wait in those goroutines for a slot.
+const maxConcurrentNotifications = 8
+
+// notifyTimeout bounds how long a single notification sink delivery may
+// take before it's abandoned.
+const notifyTimeout = 10 * time.Second
+
+// NewSupervisor builds a Supervisor from the BRANCHES and WORKFLOW_NAMES
+// env vars (both comma-separated, WORKFLOW_NAMES optional). When neither
+// is set it falls back to the single BRANCH_NAME target, preserving the
+// monitor's original single-branch behavior.
+func NewSupervisor() *Supervisor {
+	base := NewGitHubActionsMonitor()
+
+	branches := splitEnvList("BRANCHES")
+	if len(branches) == 0 && base.BranchName != "" {
+		branches = []string{base.BranchName}
+	}
+	workflows := splitEnvList("WORKFLOW_NAMES")
+	if len(workflows) == 0 {
+		workflows = []string{""} // match any workflow
+	}
+
+	var targets []jobs.Target
+	for _, branch := range branches {
+		for _, workflow := range workflows {
+			targets = append(targets, jobs.Target{Branch: branch, Workflow: workflow})
+		}
+	}
+
+	globalTimeout := 45 * time.Minute
+	if raw := os.Getenv("GLOBAL_TIMEOUT"); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil {
+			globalTimeout = parsed
+		}
+	}
+
+	sinks, sinkErrs := notify.BuildSinksFromEnv(os.Getenv)
+	for _, sinkErr := range sinkErrs {
+		fmt.Printf("⚠️ Warning: %v\n", sinkErr)
+	}
+
+	return &Supervisor{
+		Targets:       targets,
+		GlobalTimeout: globalTimeout,
+		base:          base,
+		requestSem:    make(chan struct{}, maxConcurrentGitHubRequests),
+		httpAddr:      os.Getenv("HTTP_ADDR"),
+		metrics:       httpserver.NewMetrics(),
+		dispatcher:    notify.NewDispatcher(sinks, maxConcurrentNotifications, notifyTimeout),
+		snapshot:      make(map[string]*GitHubWorkflowRun),
+		monitors:      make(map[string]*GitHubActionsMonitor),
+		logFiles:      make(map[string]string),
+		done:          make(chan struct{}),
+	}
+}
+
+// splitEnvList reads a comma-separated env var into a trimmed, non-empty
+// slice of values.
+func splitEnvList(name string) []string {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return nil
+	}
+
+	var values []string
+	for _, part := range strings.Split(raw, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			values = append(values, trimmed)
+		}
+	}
+	return values
+}
+
+// monitorFor builds the per-target GitHubActionsMonitor, reusing the
+// Supervisor's shared HTTP client and request semaphore. When there's
+// exactly one target it keeps the original log file names so existing
+// GitLab CI artifact paths don't change; otherwise it namespaces the log
+// files by target so concurrent branches/workflows don't clobber each
+// other.
+func (s *Supervisor) monitorFor(target jobs.Target) *GitHubActionsMonitor {
+	// Built field-by-field rather than as `gm := *s.base` so this doesn't
+	// copy s.base's cacheMu/rateLimitMu sync.Mutex values, and so each
+	// target gets its own cache map instead of sharing s.base's.
+	gm := &GitHubActionsMonitor{
+		GitHubToken:        s.base.GitHubToken,
+		GitHubRepo:         s.base.GitHubRepo,
+		GitLabToken:        s.base.GitLabToken,
+		GitLabProjectID:    s.base.GitLabProjectID,
+		BranchName:         target.Branch,
+		WorkflowName:       target.Workflow,
+		Environment:        s.base.Environment,
+		LogFile:            s.base.LogFile,
+		APILogFile:         s.base.APILogFile,
+		PollInterval:       s.base.PollInterval,
+		HTTPClient:         s.base.HTTPClient,
+		LogsDir:            s.base.LogsDir,
+		MaxLogArchiveMB:    s.base.MaxLogArchiveMB,
+		StateDir:           s.base.StateDir,
+		RateLimitThreshold: s.base.RateLimitThreshold,
+		MaxPollInterval:    s.base.MaxPollInterval,
+		cache:              make(map[string]*cachedGitHubResponse),
+		requestSem:         s.requestSem,
+		stateStore:         s.base.stateStore,
+		metrics:            s.metrics,
+	}
+
+	if len(s.Targets) > 1 {
+		suffix := strings.NewReplacer("/", "-", " ", "-").Replace(target.String())
+		gm.LogFile = filepath.Join(gm.LogsDir, fmt.Sprintf("github-deployment-%s-%s.log", gm.Environment, suffix))
+		gm.APILogFile = filepath.Join(gm.LogsDir, fmt.Sprintf("github-api-responses-%s-%s.log", gm.Environment, suffix))
+	}
+
+	key := target.String()
+	gm.onSnapshot = func(run *GitHubWorkflowRun) {
+		s.mu.Lock()
+		s.snapshot[key] = run
+		s.logFiles[gm.Environment] = gm.LogFile
+		s.mu.Unlock()
+	}
+	gm.onStatusChanged = func(event notify.StatusEvent) {
+		s.dispatcher.Dispatch(event, func(sink string, err error) {
+			gm.writeLog(fmt.Sprintf("⚠️ Warning: notification sink %s failed: %v", sink, err))
+		})
+	}
+
+	s.mu.Lock()
+	s.monitors[key] = gm
+	s.mu.Unlock()
+
+	return gm
+}
+
+// targetStatus is the /status payload for a single target: its most
+// recent workflow run plus the rate limit budget its monitor last saw.
+type targetStatus struct {
+	Run       *GitHubWorkflowRun `json:"run"`
+	RateLimit RateLimitStats     `json:"rate_limit"`
+}
+
+// statusSnapshot returns a JSON-marshalable copy of every tracked target's
+// most recent workflow run and rate limit budget, for the /status HTTP
+// endpoint.
+func (s *Supervisor) statusSnapshot() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make(map[string]targetStatus, len(s.snapshot))
+	for target, run := range s.snapshot {
+		entry := targetStatus{Run: run}
+		if gm, ok := s.monitors[target]; ok {
+			entry.RateLimit = gm.RateLimitStats()
+		}
+		out[target] = entry
+	}
+	return out
+}
+
+// resolveLogFile implements httpserver.LogResolver for GET /logs/{env}.
+func (s *Supervisor) resolveLogFile(environment string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path, ok := s.logFiles[environment]
+	return path, ok
+}
+
+// runTarget drives gm's poll loop for target. Concurrent GitHub API calls
+// across every target are capped by gm.requestSem, shared via
+// s.requestSem.
+func (s *Supervisor) runTarget(target jobs.Target, gm *GitHubActionsMonitor) {
+	gm.writeLog("🧪 Testing log file creation...")
+	if err := gm.validateConfig(); err != nil {
+		gm.writeLog(fmt.Sprintf("❌ Configuration error for %s: %v", target, err))
+		return
+	}
+
+	gm.writeLog(fmt.Sprintf("🚀 GitLab CI: Real-Time GitHub Actions Monitor Started for %s", target))
+	gm.writeLog(fmt.Sprintf("📁 GitHub Repository: %s", gm.GitHubRepo))
+	gm.writeLog(fmt.Sprintf("🌿 Monitoring Branch: %s", gm.BranchName))
+	if gm.WorkflowName != "" {
+		gm.writeLog(fmt.Sprintf("⚙️ Monitoring Workflow: %s", gm.WorkflowName))
+	}
+	gm.writeLog(fmt.Sprintf("🏗️ Environment: %s", strings.ToUpper(gm.Environment)))
+	gm.writeLog(fmt.Sprintf("⏰ Polling every: %v", gm.PollInterval))
+
+	state := gm.loadState()
+
+	lastInterval := gm.PollInterval
+	ticker := time.NewTicker(lastInterval)
+	defer ticker.Stop()
+
+	for {
+		terminal, err := gm.pollOnce(state)
+		if err != nil {
+			gm.writeLog(fmt.Sprintf("❌ Monitoring failed for %s: %v", target, err))
+			return
+		}
+		if terminal {
+			return
+		}
+
+		// applyBackoff may have stretched gm.PollInterval while servicing
+		// that tick; reset the ticker so the new cadence actually takes
+		// effect instead of only updating a field nobody reads again.
+		if gm.PollInterval != lastInterval {
+			ticker.Reset(gm.PollInterval)
+			lastInterval = gm.PollInterval
+		}
+
+		<-ticker.C
+	}
+}
+
+// Run starts one goroutine per target and blocks until every target
+// reaches a terminal state or GlobalTimeout elapses.
+func (s *Supervisor) Run() error {
+	if len(s.Targets) == 0 {
+		return fmt.Errorf("no monitoring targets configured (set BRANCH_NAME or BRANCHES)")
+	}
+
+	if s.httpAddr != "" {
+		srv := httpserver.NewServer(s.statusSnapshot, s.resolveLogFile)
+		go func() {
+			if err := srv.ListenAndServe(s.httpAddr); err != nil {
+				log.Printf("⚠️ HTTP status server stopped: %v", err)
+			}
+		}()
+		s.base.writeLog(fmt.Sprintf("🌐 HTTP status server listening on %s (/status, /logs/{env}, /metrics)", s.httpAddr))
+	}
+
+	var wg sync.WaitGroup
+	for _, target := range s.Targets {
+		target := target
+		gm := s.monitorFor(target)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.runTarget(target, gm)
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(s.done)
+	}()
+
+	return s.Wait()
+}
+
+// Wait blocks until every target has reached a terminal state or
+// GlobalTimeout fires, whichever comes first.
+func (s *Supervisor) Wait() error {
+	select {
+	case <-s.done:
+		return nil
+	case <-time.After(s.GlobalTimeout):
+		return fmt.Errorf("supervisor timed out after %v waiting for %d target(s)", s.GlobalTimeout, len(s.Targets))
 	}
 }
 
@@ -198,6 +606,11 @@ func (gm *GitHubActionsMonitor) writeAPILog(endpoint string, response interface{
 
 // makeGitHubRequest makes authenticated requests to GitHub API
 func (gm *GitHubActionsMonitor) makeGitHubRequest(endpoint string) (*http.Response, error) {
+	if gm.requestSem != nil {
+		gm.requestSem <- struct{}{}
+		defer func() { <-gm.requestSem }()
+	}
+
 	req, err := http.NewRequest("GET", endpoint, nil)
 	if err != nil {
 		return nil, err
@@ -207,7 +620,124 @@ func (gm *GitHubActionsMonitor) makeGitHubRequest(endpoint string) (*http.Respon
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
 	req.Header.Set("User-Agent", "GitLab-GitHub-Monitor/1.1")
 
-	return gm.HTTPClient.Do(req)
+	gm.cacheMu.Lock()
+	cached := gm.cache[endpoint]
+	gm.cacheMu.Unlock()
+	if cached != nil {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	start := time.Now()
+	resp, err := gm.HTTPClient.Do(req)
+
+	if gm.metrics != nil {
+		gm.metrics.GitHubRequestDuration.WithLabelValues(req.URL.Path).Observe(time.Since(start).Seconds())
+		code := "error"
+		if err == nil {
+			code = strconv.Itoa(resp.StatusCode)
+		}
+		gm.metrics.GitHubRequestsTotal.WithLabelValues(code).Inc()
+	}
+
+	if err != nil {
+		return resp, err
+	}
+
+	gm.recordRateLimit(resp)
+	gm.applyBackoff(resp)
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		gm.writeLog(fmt.Sprintf("💾 304 Not Modified, serving cached response for %s", endpoint))
+		resp.StatusCode = http.StatusOK
+		resp.Status = "200 OK (from cache)"
+		resp.Body = io.NopCloser(bytes.NewReader(cached.body))
+		return resp, nil
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			return resp, readErr
+		}
+
+		gm.cacheMu.Lock()
+		gm.cache[endpoint] = &cachedGitHubResponse{
+			etag:         resp.Header.Get("ETag"),
+			lastModified: resp.Header.Get("Last-Modified"),
+			body:         body,
+		}
+		gm.cacheMu.Unlock()
+
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	return resp, nil
+}
+
+// recordRateLimit parses GitHub's X-RateLimit-* headers into
+// RateLimitStats, so the HTTP status endpoint (and logs) can show
+// remaining budget.
+func (gm *GitHubActionsMonitor) recordRateLimit(resp *http.Response) {
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	limit, _ := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+
+	var resetAt time.Time
+	if resetUnix, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		resetAt = time.Unix(resetUnix, 0)
+	}
+
+	gm.rateLimitMu.Lock()
+	gm.rateLimit = RateLimitStats{Remaining: remaining, Limit: limit, ResetAt: resetAt}
+	gm.rateLimitMu.Unlock()
+}
+
+// RateLimitStats returns the last observed GitHub rate limit snapshot.
+func (gm *GitHubActionsMonitor) RateLimitStats() RateLimitStats {
+	gm.rateLimitMu.Lock()
+	defer gm.rateLimitMu.Unlock()
+	return gm.rateLimit
+}
+
+// applyBackoff extends PollInterval (capped at MaxPollInterval, doubling
+// each time) when GitHub signals we're close to the rate limit or asks us
+// to slow down via a 403/429 with Retry-After.
+func (gm *GitHubActionsMonitor) applyBackoff(resp *http.Response) {
+	stats := gm.RateLimitStats()
+
+	var retryAfterSeconds int
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+		retryAfterSeconds, _ = strconv.Atoi(resp.Header.Get("Retry-After"))
+	}
+
+	lowOnBudget := stats.Limit > 0 && stats.Remaining < gm.RateLimitThreshold
+	if retryAfterSeconds == 0 && !lowOnBudget {
+		return
+	}
+
+	next := gm.PollInterval * 2
+	if retryAfter := time.Duration(retryAfterSeconds) * time.Second; retryAfter > next {
+		next = retryAfter
+	}
+	if next > gm.MaxPollInterval {
+		next = gm.MaxPollInterval
+	}
+	if next <= gm.PollInterval {
+		return
+	}
+
+	gm.writeLog(fmt.Sprintf("⚠️ backing off: extending poll interval from %v to %v (remaining=%d/%d, retry-after=%ds)",
+		gm.PollInterval, next, stats.Remaining, stats.Limit, retryAfterSeconds))
+	gm.PollInterval = next
 }
 
 // getWorkflowRuns fetches all workflow runs
@@ -282,6 +812,144 @@ func (gm *GitHubActionsMonitor) getWorkflowJobs(runID int) (*GitHubJobsResponse,
 	return &response, nil
 }
 
+// downloadSignedLogArchive follows GitHub's 302 redirect to the short-lived
+// signed log URL and writes the response body to destPath, capping the
+// number of bytes read at MaxLogArchiveMB so a runaway archive can't fill
+// the GitLab CI runner's disk.
+func (gm *GitHubActionsMonitor) downloadSignedLogArchive(endpoint, destPath string) error {
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+gm.GitHubToken)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", "GitLab-GitHub-Monitor/1.1")
+
+	// GitHub's log endpoints respond with a 302 to a pre-signed URL; don't
+	// let the stdlib client auto-follow it, since that would leak our
+	// GitHub bearer token to whatever host the signed URL points at.
+	noRedirectClient := &http.Client{
+		Timeout: gm.HTTPClient.Timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := noRedirectClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusFound || resp.StatusCode == http.StatusTemporaryRedirect {
+		signedURL := resp.Header.Get("Location")
+		if signedURL == "" {
+			return fmt.Errorf("GitHub redirect response missing Location header")
+		}
+
+		// Reuse the same timeout as the initial request: the signed URL
+		// points at a host outside GitHub's control, and a stalled server
+		// there shouldn't be able to hang this goroutine indefinitely.
+		signedClient := &http.Client{Timeout: gm.HTTPClient.Timeout}
+
+		signedResp, err := signedClient.Get(signedURL)
+		if err != nil {
+			return fmt.Errorf("failed to fetch signed log URL: %w", err)
+		}
+		defer signedResp.Body.Close()
+
+		if signedResp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(signedResp.Body)
+			return fmt.Errorf("signed log URL returned %d: %s", signedResp.StatusCode, string(body))
+		}
+
+		return gm.writeCappedArchive(signedResp.Body, destPath)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("GitHub API error %d: %s", resp.StatusCode, string(body))
+	}
+
+	return gm.writeCappedArchive(resp.Body, destPath)
+}
+
+// writeCappedArchive streams src to destPath inside LogsDir, refusing to
+// write past MaxLogArchiveMB.
+func (gm *GitHubActionsMonitor) writeCappedArchive(src io.Reader, destPath string) error {
+	if err := gm.ensureLogDir(); err != nil {
+		return err
+	}
+
+	out, err := os.Create(destPath)
+	if err != nil {
+		return fmt.Errorf("failed to create archive %s: %w", destPath, err)
+	}
+	defer out.Close()
+
+	maxBytes := gm.MaxLogArchiveMB * 1024 * 1024
+	limited := io.LimitReader(src, maxBytes+1)
+
+	written, err := io.Copy(out, limited)
+	if err != nil {
+		return fmt.Errorf("failed to write archive %s: %w", destPath, err)
+	}
+	if written > maxBytes {
+		out.Close()
+		os.Remove(destPath)
+		return fmt.Errorf("log archive exceeded MAX_LOG_ARCHIVE_MB (%d MB)", gm.MaxLogArchiveMB)
+	}
+
+	return nil
+}
+
+// downloadJobLogs fetches the zip archive of every job's logs for a
+// workflow run and stores it in LogsDir so GitLab developers can download
+// the full GitHub-side execution logs without leaving GitLab CI.
+func (gm *GitHubActionsMonitor) downloadJobLogs(runID int) error {
+	endpoint := fmt.Sprintf("https://api.github.com/repos/%s/actions/runs/%d/logs", gm.GitHubRepo, runID)
+	destPath := filepath.Join(gm.LogsDir, fmt.Sprintf("github-logs-%s-%d.zip", gm.Environment, runID))
+
+	if err := gm.downloadSignedLogArchive(endpoint, destPath); err != nil {
+		return fmt.Errorf("failed to download job logs for run %d: %w", runID, err)
+	}
+
+	gm.writeLog(fmt.Sprintf("📦 Downloaded GitHub job logs archive: %s", destPath))
+	return nil
+}
+
+// downloadFailedJobLogs fetches and stores the raw text log for every
+// failed job in a workflow run, one file per job, so the failure can be
+// inspected without re-running `analyzeFailure` against the GitHub API.
+// jobsResp is run's jobs, already fetched by the caller.
+func (gm *GitHubActionsMonitor) downloadFailedJobLogs(run *GitHubWorkflowRun, jobsResp *GitHubJobsResponse) error {
+	if jobsResp == nil {
+		return nil
+	}
+
+	var firstErr error
+	for _, job := range jobsResp.Jobs {
+		if job.Conclusion != "failure" {
+			continue
+		}
+
+		endpoint := fmt.Sprintf("https://api.github.com/repos/%s/actions/jobs/%d/logs", gm.GitHubRepo, job.ID)
+		destPath := filepath.Join(gm.LogsDir, fmt.Sprintf("github-job-log-%s-%d.txt", gm.Environment, job.ID))
+
+		if err := gm.downloadSignedLogArchive(endpoint, destPath); err != nil {
+			gm.writeLog(fmt.Sprintf("⚠️ Could not download log for failed job %q: %v", job.Name, err))
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		gm.writeLog(fmt.Sprintf("📦 Downloaded failed job log: %s", destPath))
+	}
+
+	return firstErr
+}
+
 // updateGitLabStatus updates GitLab external pipeline status
 func (gm *GitHubActionsMonitor) updateGitLabStatus(state, description, targetURL, commitSHA string) error {
 	url := fmt.Sprintf("https://gitlab.com/api/v4/projects/%s/statuses/%s", gm.GitLabProjectID, commitSHA)
@@ -308,6 +976,10 @@ func (gm *GitHubActionsMonitor) updateGitLabStatus(state, description, targetURL
 	}
 	defer resp.Body.Close()
 
+	if gm.metrics != nil {
+		gm.metrics.GitLabStatusUpdatesTotal.WithLabelValues(state).Inc()
+	}
+
 	return nil
 }
 
@@ -357,19 +1029,40 @@ func (gm *GitHubActionsMonitor) mapToGitLabState(status, conclusion string) stri
 	}
 }
 
-// analyzeFailure provides detailed failure analysis
-func (gm *GitHubActionsMonitor) analyzeFailure(run *GitHubWorkflowRun) {
+// failedStepNames returns "job / step" for every failed step across every
+// failed job in jobsResp, for inclusion in notify.StatusEvent.FailedSteps.
+func (gm *GitHubActionsMonitor) failedStepNames(jobsResp *GitHubJobsResponse) []string {
+	if jobsResp == nil {
+		return nil
+	}
+
+	var names []string
+	for _, job := range jobsResp.Jobs {
+		if job.Conclusion != "failure" {
+			continue
+		}
+		for _, step := range job.Steps {
+			if step.Conclusion == "failure" {
+				names = append(names, fmt.Sprintf("%s / %s", job.Name, step.Name))
+			}
+		}
+	}
+	return names
+}
+
+// analyzeFailure provides detailed failure analysis. jobsResp is run's
+// jobs, already fetched by the caller.
+func (gm *GitHubActionsMonitor) analyzeFailure(run *GitHubWorkflowRun, jobsResp *GitHubJobsResponse) {
 	gm.writeLog("🔍 FAILURE ANALYSIS: Analyzing GitHub Actions deployment failure...")
 
-	jobs, err := gm.getWorkflowJobs(run.ID)
-	if err != nil {
-		gm.writeLog(fmt.Sprintf("❌ Could not fetch job details: %v", err))
+	if jobsResp == nil {
+		gm.writeLog("❌ Could not fetch job details")
 		return
 	}
 
-	gm.writeLog(fmt.Sprintf("📊 Total jobs in workflow: %d", jobs.TotalCount))
+	gm.writeLog(fmt.Sprintf("📊 Total jobs in workflow: %d", jobsResp.TotalCount))
 
-	for _, job := range jobs.Jobs {
+	for _, job := range jobsResp.Jobs {
 		gm.writeLog(fmt.Sprintf("📋 Job: %s", job.Name))
 		gm.writeLog(fmt.Sprintf("   Status: %s | Conclusion: %s", job.Status, job.Conclusion))
 
@@ -395,11 +1088,18 @@ func (gm *GitHubActionsMonitor) analyzeFailure(run *GitHubWorkflowRun) {
 		gm.writeLog(fmt.Sprintf("   🔗 Job URL: %s", job.HTMLURL))
 		gm.writeLog("   " + strings.Repeat("-", 50))
 	}
+
+	if err := gm.downloadJobLogs(run.ID); err != nil {
+		gm.writeLog(fmt.Sprintf("⚠️ Could not archive full run logs: %v", err))
+	}
+	if err := gm.downloadFailedJobLogs(run, jobsResp); err != nil {
+		gm.writeLog(fmt.Sprintf("⚠️ Could not archive one or more failed job logs: %v", err))
+	}
 }
 
 // logDetailedStatus provides comprehensive status information
 func (gm *GitHubActionsMonitor) logDetailedStatus(run *GitHubWorkflowRun) {
-	jobs, err := gm.getWorkflowJobs(run.ID)
+	jobsResp, err := gm.getWorkflowJobs(run.ID)
 	if err != nil {
 		gm.writeLog(fmt.Sprintf("⚠️ Could not fetch job details: %v", err))
 		return
@@ -408,7 +1108,7 @@ func (gm *GitHubActionsMonitor) logDetailedStatus(run *GitHubWorkflowRun) {
 	var queued, inProgress, completed, failed int
 	var currentJobs []string
 
-	for _, job := range jobs.Jobs {
+	for _, job := range jobsResp.Jobs {
 		switch job.Status {
 		case "queued":
 			queued++
@@ -434,7 +1134,7 @@ func (gm *GitHubActionsMonitor) logDetailedStatus(run *GitHubWorkflowRun) {
 	}
 
 	gm.writeLog(fmt.Sprintf("📈 Jobs Status: %d total | %d queued | %d running | %d completed | %d failed",
-		jobs.TotalCount, queued, inProgress, completed, failed))
+		jobsResp.TotalCount, queued, inProgress, completed, failed))
 
 	if len(currentJobs) > 0 {
 		gm.writeLog(fmt.Sprintf("🔄 Currently running: %s", strings.Join(currentJobs, ", ")))
@@ -461,144 +1161,231 @@ func (gm *GitHubActionsMonitor) validateConfig() error {
 	return nil
 }
 
-// startMonitoring starts the real-time monitoring process
-func (gm *GitHubActionsMonitor) startMonitoring() error {
-	// Test log file creation immediately
-	gm.writeLog("🧪 Testing log file creation...")
+// monitorState tracks the mutable state a poll loop carries between ticks.
+type monitorState struct {
+	lastStatus     string
+	lastConclusion string
+	lastRunID      int
+	start          time.Time
+}
 
-	if err := gm.validateConfig(); err != nil {
-		return fmt.Errorf("configuration error: %w", err)
+// loadState seeds a monitorState from the persisted store for gm's
+// (BranchName, WorkflowName) target, so a restarted monitor or a retried
+// GitLab CI job resumes from where it left off instead of re-reporting
+// transitions GitLab has already seen.
+func (gm *GitHubActionsMonitor) loadState() *monitorState {
+	st := &monitorState{start: time.Now()}
+
+	if gm.stateStore == nil {
+		return st
 	}
 
-	gm.writeLog("🚀 GitLab CI: Real-Time GitHub Actions Monitor Started")
-	gm.writeLog(fmt.Sprintf("📁 GitHub Repository: %s", gm.GitHubRepo))
-	gm.writeLog(fmt.Sprintf("🌿 Monitoring Branch: %s", gm.BranchName))
-	gm.writeLog(fmt.Sprintf("🏗️ Environment: %s", strings.ToUpper(gm.Environment)))
-	gm.writeLog(fmt.Sprintf("⏰ Polling every: %v", gm.PollInterval))
-	gm.writeLog(fmt.Sprintf("📝 Log file: %s", gm.LogFile))
-	gm.writeLog(fmt.Sprintf("📊 API log file: %s", gm.APILogFile))
-	gm.writeLog("👥 GitLab developers can see GitHub deployment status here")
-	gm.writeLog(strings.Repeat("=", 60))
+	saved, found, err := gm.stateStore.Load(gm.Environment, gm.BranchName, gm.WorkflowName)
+	if err != nil {
+		gm.writeLog(fmt.Sprintf("⚠️ Could not load persisted state: %v", err))
+		return st
+	}
+	if !found {
+		return st
+	}
 
-	var lastStatus, lastConclusion string
-	var lastRunID int
-	monitoringStart := time.Now()
+	gm.writeLog(fmt.Sprintf("♻️ Resuming from persisted state: Run ID %d, Status %s, Conclusion %s",
+		saved.RunID, saved.Status, saved.Conclusion))
 
-	// Main monitoring loop with regular logging
-	for {
-		currentTime := time.Now()
-		elapsed := currentTime.Sub(monitoringStart)
+	st.lastRunID = saved.RunID
+	st.lastStatus = saved.Status
+	st.lastConclusion = saved.Conclusion
+	return st
+}
 
-		// Log every 10 seconds regardless of status changes
-		gm.writeLog(fmt.Sprintf("⏰ Monitoring tick - Elapsed: %v", elapsed.Round(time.Second)))
+// saveState persists the transition currently tracked by st, along with
+// the GitLab state just reported for it, so a later restart can tell this
+// transition was already reported.
+func (gm *GitHubActionsMonitor) saveState(st *monitorState, gitlabState string) {
+	if gm.stateStore == nil {
+		return
+	}
 
-		runs, err := gm.getWorkflowRuns()
-		if err != nil {
-			gm.writeLog(fmt.Sprintf("❌ Error fetching workflow runs: %v", err))
-			time.Sleep(gm.PollInterval)
-			continue
-		}
+	err := gm.stateStore.Save(gm.Environment, gm.BranchName, gm.WorkflowName, statestore.TargetState{
+		RunID:           st.lastRunID,
+		Status:          st.lastStatus,
+		Conclusion:      st.lastConclusion,
+		LastGitLabState: gitlabState,
+	})
+	if err != nil {
+		gm.writeLog(fmt.Sprintf("⚠️ Could not persist state: %v", err))
+	}
+}
 
-		gm.writeLog(fmt.Sprintf("📊 Found %d workflow runs", runs.TotalCount))
+// Reset purges any persisted state for branch across all environments, so
+// the next run treats it as never having been monitored before.
+func (gm *GitHubActionsMonitor) Reset(branch string) error {
+	if gm.stateStore == nil {
+		return nil
+	}
+	return gm.stateStore.Reset(branch)
+}
 
-		var currentRun *GitHubWorkflowRun
-		for _, run := range runs.WorkflowRuns {
-			// Modified: Match by branch name instead of commit SHA
-			if run.HeadBranch == gm.BranchName {
-				detailed, err := gm.getSpecificWorkflowRun(run.ID)
-				if err == nil {
-					currentRun = detailed
-					break
-				}
+// pollOnce fetches the current workflow run for gm's (BranchName,
+// WorkflowName) target, logs its status, reports GitLab status transitions,
+// and reports whether the target has reached a terminal state (completed
+// or timed out). It is the unit of work a single poll tick performs in the
+// Supervisor's per-target loop (runTarget).
+func (gm *GitHubActionsMonitor) pollOnce(state *monitorState) (terminal bool, err error) {
+	elapsed := time.Since(state.start)
+	gm.writeLog(fmt.Sprintf("⏰ Monitoring tick - Elapsed: %v", elapsed.Round(time.Second)))
+
+	runs, err := gm.getWorkflowRuns()
+	if err != nil {
+		gm.writeLog(fmt.Sprintf("❌ Error fetching workflow runs: %v", err))
+		return false, nil
+	}
+
+	gm.writeLog(fmt.Sprintf("📊 Found %d workflow runs", runs.TotalCount))
+
+	var currentRun *GitHubWorkflowRun
+	for _, run := range runs.WorkflowRuns {
+		// Modified: Match by branch name instead of commit SHA
+		if run.HeadBranch == gm.BranchName && (gm.WorkflowName == "" || run.Name == gm.WorkflowName) {
+			detailed, err := gm.getSpecificWorkflowRun(run.ID)
+			if err == nil {
+				currentRun = detailed
+				break
 			}
 		}
+	}
 
-		if currentRun == nil {
-			gm.writeLog(fmt.Sprintf("⏳ No workflow found for branch %s yet...", gm.BranchName))
-			time.Sleep(gm.PollInterval)
-			continue
-		}
+	if currentRun == nil {
+		gm.writeLog(fmt.Sprintf("⏳ No workflow found for branch %s yet...", gm.BranchName))
+		return false, nil
+	}
 
-		// Always log current status
-		statusChanged := currentRun.Status != lastStatus ||
-			currentRun.Conclusion != lastConclusion ||
-			currentRun.ID != lastRunID
+	if gm.onSnapshot != nil {
+		gm.onSnapshot(currentRun)
+	}
 
-		symbol := gm.getStatusSymbol(currentRun.Status, currentRun.Conclusion)
-		statusMsg := fmt.Sprintf("%s GitHub Actions (%s): %s", symbol, strings.ToUpper(gm.Environment), strings.ToUpper(currentRun.Status))
+	// Always log current status
+	statusChanged := currentRun.Status != state.lastStatus ||
+		currentRun.Conclusion != state.lastConclusion ||
+		currentRun.ID != state.lastRunID
 
-		if currentRun.Status == "completed" && currentRun.Conclusion != "" {
-			statusMsg += fmt.Sprintf(" (%s)", strings.ToUpper(currentRun.Conclusion))
-		}
+	symbol := gm.getStatusSymbol(currentRun.Status, currentRun.Conclusion)
+	statusMsg := fmt.Sprintf("%s GitHub Actions (%s): %s", symbol, strings.ToUpper(gm.Environment), strings.ToUpper(currentRun.Status))
 
-		statusMsg += fmt.Sprintf(" | Run ID: %d | Branch: %s", currentRun.ID, currentRun.HeadBranch)
-		gm.writeLog(statusMsg)
+	if currentRun.Status == "completed" && currentRun.Conclusion != "" {
+		statusMsg += fmt.Sprintf(" (%s)", strings.ToUpper(currentRun.Conclusion))
+	}
 
-		if statusChanged {
-			gm.writeLog(fmt.Sprintf("🔗 GitHub URL: %s", currentRun.HTMLURL))
+	statusMsg += fmt.Sprintf(" | Run ID: %d | Branch: %s", currentRun.ID, currentRun.HeadBranch)
+	gm.writeLog(statusMsg)
+
+	// Fetched once and shared by failedStepNames/analyzeFailure/
+	// downloadFailedJobLogs below, instead of each refetching the same
+	// run's jobs from GitHub.
+	var failedJobs *GitHubJobsResponse
+	if currentRun.Status == "completed" && currentRun.Conclusion == "failure" {
+		var jobsErr error
+		failedJobs, jobsErr = gm.getWorkflowJobs(currentRun.ID)
+		if jobsErr != nil {
+			gm.writeLog(fmt.Sprintf("⚠️ Could not fetch job details: %v", jobsErr))
+		}
+	}
 
-			// Log detailed status
-			gm.logDetailedStatus(currentRun)
+	if statusChanged {
+		previousStatus := state.lastStatus
+		previousConclusion := state.lastConclusion
 
-			// Update GitLab external status
-			gitlabState := gm.mapToGitLabState(currentRun.Status, currentRun.Conclusion)
-			description := fmt.Sprintf("GitHub Actions (%s): %s", gm.Environment, currentRun.Status)
-			if currentRun.Conclusion != "" {
-				description += fmt.Sprintf(" (%s)", currentRun.Conclusion)
-			}
+		gm.writeLog(fmt.Sprintf("🔗 GitHub URL: %s", currentRun.HTMLURL))
 
-			if err := gm.updateGitLabStatus(gitlabState, description, currentRun.HTMLURL, currentRun.HeadSHA); err != nil {
-				gm.writeLog(fmt.Sprintf("⚠️ Warning: GitLab status update failed: %v", err))
-			} else {
-				gm.writeLog(fmt.Sprintf("✅ GitLab external status updated: %s", gitlabState))
-			}
+		// Log detailed status
+		gm.logDetailedStatus(currentRun)
 
-			lastStatus = currentRun.Status
-			lastConclusion = currentRun.Conclusion
-			lastRunID = currentRun.ID
+		// Update GitLab external status
+		gitlabState := gm.mapToGitLabState(currentRun.Status, currentRun.Conclusion)
+		description := fmt.Sprintf("GitHub Actions (%s): %s", gm.Environment, currentRun.Status)
+		if currentRun.Conclusion != "" {
+			description += fmt.Sprintf(" (%s)", currentRun.Conclusion)
 		}
 
-		// Handle completion
-		if currentRun.Status == "completed" {
-			totalDuration := time.Since(monitoringStart).Round(time.Second)
-			symbol := gm.getStatusSymbol(currentRun.Status, currentRun.Conclusion)
-
-			gm.writeLog(fmt.Sprintf("%s GitHub Actions deployment completed: %s",
-				symbol, strings.ToUpper(currentRun.Conclusion)))
+		if err := gm.updateGitLabStatus(gitlabState, description, currentRun.HTMLURL, currentRun.HeadSHA); err != nil {
+			gm.writeLog(fmt.Sprintf("⚠️ Warning: GitLab status update failed: %v", err))
+		} else {
+			gm.writeLog(fmt.Sprintf("✅ GitLab external status updated: %s", gitlabState))
+		}
 
-			if currentRun.Conclusion == "success" {
-				gm.writeLog(fmt.Sprintf("🎉 GitHub Actions deployment to %s SUCCESSFUL!", strings.ToUpper(gm.Environment)))
-			} else if currentRun.Conclusion == "failure" {
-				gm.writeLog(fmt.Sprintf("💥 GitHub Actions deployment to %s FAILED!", strings.ToUpper(gm.Environment)))
-				gm.analyzeFailure(currentRun)
-			} else if currentRun.Conclusion == "cancelled" {
-				gm.writeLog(fmt.Sprintf("⚠️ GitHub Actions deployment to %s was CANCELLED", strings.ToUpper(gm.Environment)))
+		state.lastStatus = currentRun.Status
+		state.lastConclusion = currentRun.Conclusion
+		state.lastRunID = currentRun.ID
+		gm.saveState(state, gitlabState)
+
+		if gm.onStatusChanged != nil {
+			event := notify.StatusEvent{
+				Environment:        gm.Environment,
+				Branch:             gm.BranchName,
+				Workflow:           gm.WorkflowName,
+				RunURL:             currentRun.HTMLURL,
+				PreviousStatus:     previousStatus,
+				PreviousConclusion: previousConclusion,
+				Status:             currentRun.Status,
+				Conclusion:         currentRun.Conclusion,
+				Duration:           time.Since(state.start),
+				OccurredAt:         time.Now(),
+			}
+			if currentRun.Status == "completed" && currentRun.Conclusion == "failure" {
+				event.FailedSteps = gm.failedStepNames(failedJobs)
 			}
+			gm.onStatusChanged(event)
+		}
+	}
 
-			gm.writeLog(fmt.Sprintf("⏱️ Total monitoring duration: %v", totalDuration))
-			gm.writeLog(strings.Repeat("=", 60))
-			gm.writeLog("🏁 Real-time monitoring completed!")
-			gm.writeLog("📊 Complete GitHub API responses saved to: " + gm.APILogFile)
-			gm.writeLog("📋 This log available as GitLab CI artifact")
-			break
+	// Handle completion
+	if currentRun.Status == "completed" {
+		totalDuration := time.Since(state.start).Round(time.Second)
+		symbol := gm.getStatusSymbol(currentRun.Status, currentRun.Conclusion)
+
+		if gm.metrics != nil {
+			gm.metrics.WorkflowRunDuration.WithLabelValues(currentRun.Conclusion).Observe(time.Since(state.start).Seconds())
 		}
 
-		// Add timeout check
-		if elapsed > 45*time.Minute {
-			gm.writeLog("⏰ Monitoring timeout reached (45 minutes)")
-			break
+		gm.writeLog(fmt.Sprintf("%s GitHub Actions deployment completed: %s",
+			symbol, strings.ToUpper(currentRun.Conclusion)))
+
+		if currentRun.Conclusion == "success" {
+			gm.writeLog(fmt.Sprintf("🎉 GitHub Actions deployment to %s SUCCESSFUL!", strings.ToUpper(gm.Environment)))
+		} else if currentRun.Conclusion == "failure" {
+			gm.writeLog(fmt.Sprintf("💥 GitHub Actions deployment to %s FAILED!", strings.ToUpper(gm.Environment)))
+			gm.analyzeFailure(currentRun, failedJobs)
+		} else if currentRun.Conclusion == "cancelled" {
+			gm.writeLog(fmt.Sprintf("⚠️ GitHub Actions deployment to %s was CANCELLED", strings.ToUpper(gm.Environment)))
 		}
 
-		time.Sleep(gm.PollInterval)
+		gm.writeLog(fmt.Sprintf("⏱️ Total monitoring duration: %v", totalDuration))
+		gm.writeLog(strings.Repeat("=", 60))
+		gm.writeLog("🏁 Real-time monitoring completed!")
+		gm.writeLog("📊 Complete GitHub API responses saved to: " + gm.APILogFile)
+		gm.writeLog("📋 This log available as GitLab CI artifact")
+		return true, nil
 	}
 
-	return nil
+	// Add timeout check
+	if elapsed > 45*time.Minute {
+		gm.writeLog("⏰ Monitoring timeout reached (45 minutes)")
+		return true, nil
+	}
+
+	return false, nil
 }
 
 func main() {
-	monitor := NewGitHubActionsMonitor()
+	stateDir := flag.String("state-dir", "", "directory for the persistent monitor state store (overrides STATE_DIR)")
+	flag.Parse()
+	if *stateDir != "" {
+		os.Setenv("STATE_DIR", *stateDir)
+	}
+
+	supervisor := NewSupervisor()
 
-	if err := monitor.startMonitoring(); err != nil {
+	if err := supervisor.Run(); err != nil {
 		log.Printf("❌ GitHub Actions monitoring failed: %v", err)
 		os.Exit(1)
 	}
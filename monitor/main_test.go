@@ -0,0 +1,127 @@
+// monitor/main_test.go
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestMonitor(t *testing.T) *GitHubActionsMonitor {
+	t.Helper()
+
+	logsDir := t.TempDir()
+	return &GitHubActionsMonitor{
+		LogsDir:         logsDir,
+		LogFile:         filepath.Join(logsDir, "test.log"),
+		MaxLogArchiveMB: 1,
+	}
+}
+
+// TestWriteCappedArchiveWritesWithinLimit checks that a src smaller than
+// MaxLogArchiveMB is written to destPath in full.
+func TestWriteCappedArchiveWritesWithinLimit(t *testing.T) {
+	gm := newTestMonitor(t)
+	destPath := filepath.Join(gm.LogsDir, "archive.txt")
+	want := []byte("small log contents")
+
+	if err := gm.writeCappedArchive(bytes.NewReader(want), destPath); err != nil {
+		t.Fatalf("writeCappedArchive() error: %v", err)
+	}
+
+	got, err := os.ReadFile(destPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("archive contents = %q; want %q", got, want)
+	}
+}
+
+// TestWriteCappedArchiveRejectsOversizedSrc checks that a src larger than
+// MaxLogArchiveMB is rejected and the partial file is removed.
+func TestWriteCappedArchiveRejectsOversizedSrc(t *testing.T) {
+	gm := newTestMonitor(t)
+	destPath := filepath.Join(gm.LogsDir, "archive.txt")
+	oversized := bytes.Repeat([]byte("x"), int(gm.MaxLogArchiveMB*1024*1024)+1)
+
+	err := gm.writeCappedArchive(bytes.NewReader(oversized), destPath)
+	if err == nil {
+		t.Fatal("writeCappedArchive() error = nil; want an error for an oversized src")
+	}
+
+	if _, statErr := os.Stat(destPath); !os.IsNotExist(statErr) {
+		t.Errorf("destPath still exists after rejecting an oversized archive: %v", statErr)
+	}
+}
+
+// TestApplyBackoffDoublesIntervalWhenLowOnBudget checks that PollInterval
+// doubles once remaining rate limit budget drops below RateLimitThreshold.
+func TestApplyBackoffDoublesIntervalWhenLowOnBudget(t *testing.T) {
+	gm := newTestMonitor(t)
+	gm.PollInterval = 10 * time.Second
+	gm.MaxPollInterval = 5 * time.Minute
+	gm.RateLimitThreshold = 100
+	gm.rateLimit = RateLimitStats{Remaining: 5, Limit: 5000}
+
+	gm.applyBackoff(&http.Response{StatusCode: http.StatusOK, Header: http.Header{}})
+
+	if want := 20 * time.Second; gm.PollInterval != want {
+		t.Errorf("PollInterval = %v; want %v", gm.PollInterval, want)
+	}
+}
+
+// TestApplyBackoffNoopWhenBudgetFine checks that PollInterval is left
+// alone when there's no rate limit pressure and no Retry-After.
+func TestApplyBackoffNoopWhenBudgetFine(t *testing.T) {
+	gm := newTestMonitor(t)
+	gm.PollInterval = 10 * time.Second
+	gm.MaxPollInterval = 5 * time.Minute
+	gm.RateLimitThreshold = 100
+	gm.rateLimit = RateLimitStats{Remaining: 4000, Limit: 5000}
+
+	gm.applyBackoff(&http.Response{StatusCode: http.StatusOK, Header: http.Header{}})
+
+	if want := 10 * time.Second; gm.PollInterval != want {
+		t.Errorf("PollInterval = %v; want %v (unchanged)", gm.PollInterval, want)
+	}
+}
+
+// TestApplyBackoffRespectsMaxPollInterval checks that doubling never
+// stretches PollInterval past MaxPollInterval.
+func TestApplyBackoffRespectsMaxPollInterval(t *testing.T) {
+	gm := newTestMonitor(t)
+	gm.PollInterval = 4 * time.Minute
+	gm.MaxPollInterval = 5 * time.Minute
+	gm.RateLimitThreshold = 100
+	gm.rateLimit = RateLimitStats{Remaining: 5, Limit: 5000}
+
+	gm.applyBackoff(&http.Response{StatusCode: http.StatusOK, Header: http.Header{}})
+
+	if gm.PollInterval != gm.MaxPollInterval {
+		t.Errorf("PollInterval = %v; want capped at MaxPollInterval %v", gm.PollInterval, gm.MaxPollInterval)
+	}
+}
+
+// TestApplyBackoffHonorsRetryAfterHeader checks that a 429/403 with a
+// Retry-After longer than the doubled interval wins.
+func TestApplyBackoffHonorsRetryAfterHeader(t *testing.T) {
+	gm := newTestMonitor(t)
+	gm.PollInterval = 10 * time.Second
+	gm.MaxPollInterval = 5 * time.Minute
+	gm.RateLimitThreshold = 100
+	gm.rateLimit = RateLimitStats{Remaining: 4000, Limit: 5000}
+
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"60"}},
+	}
+	gm.applyBackoff(resp)
+
+	if want := 60 * time.Second; gm.PollInterval != want {
+		t.Errorf("PollInterval = %v; want %v", gm.PollInterval, want)
+	}
+}
@@ -0,0 +1,288 @@
+// monitor/notify/notify.go
+// Package notify implements pluggable sinks that receive the monitor's
+// status transitions: Slack, an arbitrary signed HTTP webhook, and Kafka.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// StatusEvent describes a single status transition observed by the
+// monitor, handed to every configured Notifier.
+type StatusEvent struct {
+	Environment        string        `json:"environment"`
+	Branch             string        `json:"branch"`
+	Workflow           string        `json:"workflow,omitempty"`
+	RunURL             string        `json:"run_url"`
+	PreviousStatus     string        `json:"previous_status"`
+	PreviousConclusion string        `json:"previous_conclusion"`
+	Status             string        `json:"status"`
+	Conclusion         string        `json:"conclusion"`
+	Duration           time.Duration `json:"duration"`
+	FailedSteps        []string      `json:"failed_steps,omitempty"`
+	OccurredAt         time.Time     `json:"occurred_at"`
+}
+
+// Notifier is a sink that receives StatusEvents. Implementations should
+// respect ctx's deadline; a returned error is logged by the caller but
+// never aborts monitoring.
+type Notifier interface {
+	Notify(ctx context.Context, event StatusEvent) error
+}
+
+// Dispatcher fans a StatusEvent out to every configured Notifier through a
+// bounded worker pool, so a slow or unreachable sink never blocks the
+// monitor's poll loop.
+type Dispatcher struct {
+	sinks   []Notifier
+	timeout time.Duration
+	sem     chan struct{}
+}
+
+// NewDispatcher builds a Dispatcher that runs at most maxConcurrent sink
+// deliveries at once, each bounded by timeout.
+func NewDispatcher(sinks []Notifier, maxConcurrent int, timeout time.Duration) *Dispatcher {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	return &Dispatcher{sinks: sinks, timeout: timeout, sem: make(chan struct{}, maxConcurrent)}
+}
+
+// Dispatch fires event at every sink concurrently and returns immediately;
+// onError (if non-nil) is called for each sink that fails, but a failure
+// never blocks or aborts the caller.
+func (d *Dispatcher) Dispatch(event StatusEvent, onError func(sink string, err error)) {
+	for _, sink := range d.sinks {
+		sink := sink
+		go func() {
+			d.sem <- struct{}{}
+			defer func() { <-d.sem }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+			defer cancel()
+
+			if err := sink.Notify(ctx, event); err != nil && onError != nil {
+				onError(fmt.Sprintf("%T", sink), err)
+			}
+		}()
+	}
+}
+
+// SlackNotifier posts a Block Kit message to a Slack incoming webhook.
+type SlackNotifier struct {
+	WebhookURL string
+	HTTPClient *http.Client
+}
+
+// NewSlackNotifier builds a SlackNotifier for the given incoming webhook
+// URL.
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{WebhookURL: webhookURL, HTTPClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, event StatusEvent) error {
+	symbol := ":white_check_mark:"
+	switch event.Conclusion {
+	case "failure":
+		symbol = ":x:"
+	case "cancelled":
+		symbol = ":warning:"
+	}
+
+	summary := fmt.Sprintf("%s *%s* (%s): %s", symbol, strings.ToUpper(event.Environment), event.Branch, strings.ToUpper(event.Status))
+	if event.Conclusion != "" {
+		summary += fmt.Sprintf(" (%s)", strings.ToUpper(event.Conclusion))
+	}
+
+	blocks := []map[string]interface{}{
+		{
+			"type": "section",
+			"text": map[string]string{"type": "mrkdwn", "text": summary},
+		},
+		{
+			"type": "context",
+			"elements": []map[string]string{
+				{"type": "mrkdwn", "text": fmt.Sprintf("<%s|View run> • %v", event.RunURL, event.Duration.Round(time.Second))},
+			},
+		},
+	}
+
+	if len(event.FailedSteps) > 0 {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]string{"type": "mrkdwn", "text": "*Failed steps:*\n" + strings.Join(event.FailedSteps, "\n")},
+		})
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{"blocks": blocks})
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", n.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// HTTPNotifier POSTs the StatusEvent as JSON to an arbitrary URL, signing
+// the body with HMAC-SHA256 when a secret is configured.
+type HTTPNotifier struct {
+	URL        string
+	Secret     string
+	HTTPClient *http.Client
+}
+
+// NewHTTPNotifier builds an HTTPNotifier. secret may be empty to disable
+// signing.
+func NewHTTPNotifier(url, secret string) *HTTPNotifier {
+	return &HTTPNotifier{URL: url, Secret: secret, HTTPClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (n *HTTPNotifier) Notify(ctx context.Context, event StatusEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal status event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if n.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(n.Secret))
+		mac.Write(body)
+		req.Header.Set("X-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := n.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned %d", n.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// KafkaNotifier produces the StatusEvent as a JSON message to a Kafka
+// topic.
+type KafkaNotifier struct {
+	Writer *kafka.Writer
+}
+
+// NewKafkaNotifier builds a KafkaNotifier that produces to topic across
+// brokers.
+func NewKafkaNotifier(brokers []string, topic string) *KafkaNotifier {
+	return &KafkaNotifier{
+		Writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.LeastBytes{},
+			RequiredAcks: kafka.RequireOne,
+		},
+	}
+}
+
+func (n *KafkaNotifier) Notify(ctx context.Context, event StatusEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal status event: %w", err)
+	}
+
+	return n.Writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.Environment + "/" + event.Branch),
+		Value: body,
+		Time:  event.OccurredAt,
+	})
+}
+
+// Close releases the underlying Kafka writer's connections.
+func (n *KafkaNotifier) Close() error {
+	return n.Writer.Close()
+}
+
+// BuildSinksFromEnv constructs the Notifiers named in NOTIFY_SINKS
+// (comma-separated: slack, http, kafka), reading each sink's config
+// through getenv. Unknown or misconfigured sink names are skipped, with
+// an error appended to the returned slice so the caller can log them.
+func BuildSinksFromEnv(getenv func(string) string) ([]Notifier, []error) {
+	var sinks []Notifier
+	var errs []error
+
+	for _, name := range splitCSV(getenv("NOTIFY_SINKS")) {
+		switch name {
+		case "slack":
+			url := getenv("SLACK_WEBHOOK_URL")
+			if url == "" {
+				errs = append(errs, fmt.Errorf("slack sink enabled but SLACK_WEBHOOK_URL is not set"))
+				continue
+			}
+			sinks = append(sinks, NewSlackNotifier(url))
+
+		case "http":
+			url := getenv("NOTIFY_HTTP_URL")
+			if url == "" {
+				errs = append(errs, fmt.Errorf("http sink enabled but NOTIFY_HTTP_URL is not set"))
+				continue
+			}
+			sinks = append(sinks, NewHTTPNotifier(url, getenv("NOTIFY_HTTP_SECRET")))
+
+		case "kafka":
+			brokers := splitCSV(getenv("KAFKA_BROKERS"))
+			topic := getenv("KAFKA_TOPIC")
+			if len(brokers) == 0 || topic == "" {
+				errs = append(errs, fmt.Errorf("kafka sink enabled but KAFKA_BROKERS/KAFKA_TOPIC are not set"))
+				continue
+			}
+			sinks = append(sinks, NewKafkaNotifier(brokers, topic))
+
+		default:
+			errs = append(errs, fmt.Errorf("unknown notify sink %q", name))
+		}
+	}
+
+	return sinks, errs
+}
+
+// splitCSV reads a comma-separated string into a trimmed, non-empty slice.
+func splitCSV(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+
+	var values []string
+	for _, part := range strings.Split(raw, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			values = append(values, trimmed)
+		}
+	}
+	return values
+}
@@ -0,0 +1,82 @@
+// monitor/notify/notify_test.go
+package notify
+
+import "testing"
+
+func envFrom(values map[string]string) func(string) string {
+	return func(key string) string { return values[key] }
+}
+
+// TestBuildSinksFromEnvBuildsConfiguredSinks checks that a sink is built
+// for each well-configured name in NOTIFY_SINKS.
+func TestBuildSinksFromEnvBuildsConfiguredSinks(t *testing.T) {
+	getenv := envFrom(map[string]string{
+		"NOTIFY_SINKS":      "slack, http, kafka",
+		"SLACK_WEBHOOK_URL": "https://hooks.slack.test/abc",
+		"NOTIFY_HTTP_URL":   "https://example.test/webhook",
+		"KAFKA_BROKERS":     "broker1:9092,broker2:9092",
+		"KAFKA_TOPIC":       "deployments",
+	})
+
+	sinks, errs := BuildSinksFromEnv(getenv)
+	if len(errs) != 0 {
+		t.Fatalf("errs = %v; want none", errs)
+	}
+	if len(sinks) != 3 {
+		t.Fatalf("len(sinks) = %d; want 3", len(sinks))
+	}
+
+	if _, ok := sinks[0].(*SlackNotifier); !ok {
+		t.Errorf("sinks[0] = %T; want *SlackNotifier", sinks[0])
+	}
+	if _, ok := sinks[1].(*HTTPNotifier); !ok {
+		t.Errorf("sinks[1] = %T; want *HTTPNotifier", sinks[1])
+	}
+	if _, ok := sinks[2].(*KafkaNotifier); !ok {
+		t.Errorf("sinks[2] = %T; want *KafkaNotifier", sinks[2])
+	}
+}
+
+// TestBuildSinksFromEnvSkipsMisconfiguredSinks checks that a sink missing
+// its required config is skipped with an error, without blocking the
+// other configured sinks.
+func TestBuildSinksFromEnvSkipsMisconfiguredSinks(t *testing.T) {
+	getenv := envFrom(map[string]string{
+		"NOTIFY_SINKS":    "slack, http",
+		"NOTIFY_HTTP_URL": "https://example.test/webhook",
+	})
+
+	sinks, errs := BuildSinksFromEnv(getenv)
+	if len(sinks) != 1 {
+		t.Fatalf("len(sinks) = %d; want 1 (only http)", len(sinks))
+	}
+	if _, ok := sinks[0].(*HTTPNotifier); !ok {
+		t.Errorf("sinks[0] = %T; want *HTTPNotifier", sinks[0])
+	}
+	if len(errs) != 1 {
+		t.Fatalf("len(errs) = %d; want 1 (missing SLACK_WEBHOOK_URL)", len(errs))
+	}
+}
+
+// TestBuildSinksFromEnvUnknownSink checks that an unrecognized sink name
+// is reported as an error and produces no sink.
+func TestBuildSinksFromEnvUnknownSink(t *testing.T) {
+	getenv := envFrom(map[string]string{"NOTIFY_SINKS": "carrier-pigeon"})
+
+	sinks, errs := BuildSinksFromEnv(getenv)
+	if len(sinks) != 0 {
+		t.Fatalf("len(sinks) = %d; want 0", len(sinks))
+	}
+	if len(errs) != 1 {
+		t.Fatalf("len(errs) = %d; want 1", len(errs))
+	}
+}
+
+// TestBuildSinksFromEnvNoneConfigured checks the default (NOTIFY_SINKS
+// unset) produces neither sinks nor errors.
+func TestBuildSinksFromEnvNoneConfigured(t *testing.T) {
+	sinks, errs := BuildSinksFromEnv(envFrom(nil))
+	if len(sinks) != 0 || len(errs) != 0 {
+		t.Errorf("sinks=%v errs=%v; want both empty", sinks, errs)
+	}
+}
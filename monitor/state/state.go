@@ -0,0 +1,120 @@
+// monitor/state/state.go
+// Package state persists per-(environment, branch, workflow) monitoring
+// progress in a local BoltDB file so a retried GitLab CI job or a
+// restarted container resumes instead of re-reporting transitions GitLab
+// has already seen. The bucket layout is modeled after Atlantis's
+// boltdb.go check-runs bucket: one bucket per environment, keys
+// "branch||workflow" within it (so the full key space is effectively
+// env||branch||workflow), values gob-encoded.
+package state
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// TargetState is the last observed progress for a single (branch,
+// workflow) target within an environment's bucket.
+type TargetState struct {
+	RunID           int
+	Status          string
+	Conclusion      string
+	LastGitLabState string
+}
+
+// Store wraps a BoltDB file with one bucket per environment.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens (creating if necessary) the BoltDB file at path.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state store %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close releases the underlying BoltDB file.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// key builds the "branch||workflow" key used within an environment's
+// bucket. workflow may be empty.
+func key(branch, workflow string) []byte {
+	return []byte(branch + "||" + workflow)
+}
+
+// Load returns the last recorded TargetState for (environment, branch,
+// workflow), and false if nothing has been recorded yet.
+func (s *Store) Load(environment, branch, workflow string) (TargetState, bool, error) {
+	var target TargetState
+	var found bool
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(environment))
+		if bucket == nil {
+			return nil
+		}
+
+		raw := bucket.Get(key(branch, workflow))
+		if raw == nil {
+			return nil
+		}
+
+		if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(&target); err != nil {
+			return fmt.Errorf("failed to decode state for %s/%s/%s: %w", environment, branch, workflow, err)
+		}
+		found = true
+		return nil
+	})
+
+	return target, found, err
+}
+
+// Save records the TargetState for (environment, branch, workflow),
+// creating the environment's bucket on first use.
+func (s *Store) Save(environment, branch, workflow string, target TargetState) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(environment))
+		if err != nil {
+			return fmt.Errorf("failed to create bucket %s: %w", environment, err)
+		}
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(target); err != nil {
+			return fmt.Errorf("failed to encode state for %s/%s/%s: %w", environment, branch, workflow, err)
+		}
+
+		return bucket.Put(key(branch, workflow), buf.Bytes())
+	})
+}
+
+// Reset deletes every recorded entry for branch across all environment
+// buckets, so the next run starts clean instead of replaying stale state.
+func (s *Store) Reset(branch string) error {
+	prefix := []byte(branch + "||")
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(_ []byte, bucket *bolt.Bucket) error {
+			var staleKeys [][]byte
+
+			c := bucket.Cursor()
+			for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+				staleKeys = append(staleKeys, append([]byte(nil), k...))
+			}
+
+			for _, k := range staleKeys {
+				if err := bucket.Delete(k); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
@@ -0,0 +1,85 @@
+// monitor/state/state_test.go
+package state
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	store, err := Open(filepath.Join(t.TempDir(), "monitor.db"))
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+// TestStoreSaveLoadRoundTrip checks that a saved TargetState comes back
+// unchanged for the same (environment, branch, workflow) key.
+func TestStoreSaveLoadRoundTrip(t *testing.T) {
+	store := openTestStore(t)
+
+	want := TargetState{RunID: 42, Status: "completed", Conclusion: "failure", LastGitLabState: "failed"}
+	if err := store.Save("qa", "main", "deploy", want); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	got, found, err := store.Load("qa", "main", "deploy")
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !found {
+		t.Fatal("Load() found = false; want true")
+	}
+	if got != want {
+		t.Errorf("Load() = %+v; want %+v", got, want)
+	}
+}
+
+// TestStoreLoadMissingNotFound checks that Load reports found=false
+// instead of an error for a key that was never saved.
+func TestStoreLoadMissingNotFound(t *testing.T) {
+	store := openTestStore(t)
+
+	_, found, err := store.Load("qa", "main", "deploy")
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if found {
+		t.Error("Load() found = true; want false for an unseeded key")
+	}
+}
+
+// TestStoreResetClearsBranchAcrossEnvironments checks that Reset deletes
+// a branch's entries from every environment bucket but leaves other
+// branches untouched.
+func TestStoreResetClearsBranchAcrossEnvironments(t *testing.T) {
+	store := openTestStore(t)
+
+	if err := store.Save("qa", "main", "deploy", TargetState{RunID: 1}); err != nil {
+		t.Fatalf("Save(qa) error: %v", err)
+	}
+	if err := store.Save("prod", "main", "deploy", TargetState{RunID: 2}); err != nil {
+		t.Fatalf("Save(prod) error: %v", err)
+	}
+	if err := store.Save("qa", "other-branch", "deploy", TargetState{RunID: 3}); err != nil {
+		t.Fatalf("Save(other-branch) error: %v", err)
+	}
+
+	if err := store.Reset("main"); err != nil {
+		t.Fatalf("Reset() error: %v", err)
+	}
+
+	if _, found, err := store.Load("qa", "main", "deploy"); err != nil || found {
+		t.Errorf("Load(qa, main) after Reset: found=%v err=%v; want found=false", found, err)
+	}
+	if _, found, err := store.Load("prod", "main", "deploy"); err != nil || found {
+		t.Errorf("Load(prod, main) after Reset: found=%v err=%v; want found=false", found, err)
+	}
+	if _, found, err := store.Load("qa", "other-branch", "deploy"); err != nil || !found {
+		t.Errorf("Load(qa, other-branch) after Reset: found=%v err=%v; want found=true", found, err)
+	}
+}